@@ -0,0 +1,76 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// configContextKey 是 WithConfig 中间件写入路由组级 Config 时使用的上下文键
+const configContextKey = "response_config"
+
+// Config 描述 Success/Error 等助手函数最终如何渲染响应
+type Config struct {
+	Renderer Renderer // 为空时按Accept头协商内置Renderer
+	Envelope Envelope // 为空时使用 DefaultEnvelope
+}
+
+// globalConfig 是未设置路由组级 Config 时使用的全局配置
+var globalConfig = Config{Envelope: DefaultEnvelope}
+
+// SetConfig 设置全局默认的 Renderer/Envelope
+func SetConfig(cfg Config) {
+	if cfg.Envelope == nil {
+		cfg.Envelope = DefaultEnvelope
+	}
+	globalConfig = cfg
+}
+
+// WithConfig 返回一个中间件,为其所在的路由组覆盖 Renderer/Envelope
+func WithConfig(cfg Config) gin.HandlerFunc {
+	if cfg.Envelope == nil {
+		cfg.Envelope = DefaultEnvelope
+	}
+	return func(c *gin.Context) {
+		c.Set(configContextKey, cfg)
+		c.Next()
+	}
+}
+
+// resolveConfig 优先返回路由组级 Config,否则返回全局 Config
+func resolveConfig(c *gin.Context) Config {
+	if v, exists := c.Get(configContextKey); exists {
+		if cfg, ok := v.(Config); ok {
+			return cfg
+		}
+	}
+	return globalConfig
+}
+
+// renderResponse 按当前生效的 Envelope/Renderer 输出resp
+func renderResponse(c *gin.Context, httpCode int, resp *Response) {
+	setResponseCode(c, resp.Code)
+
+	cfg := resolveConfig(c)
+	envelope := cfg.Envelope
+	if envelope == nil {
+		envelope = DefaultEnvelope
+	}
+	renderer := cfg.Renderer
+	if renderer == nil {
+		renderer = negotiateRenderer(c)
+	}
+
+	payload := envelope.Wrap(resp)
+	if err := renderer.Render(c, httpCode, payload); err != nil {
+		// 协商出的Renderer编码失败(如proto.Marshal或msgpack编码返回错误):
+		// 回退到JSON,避免请求在没有写出任何状态/响应体的情况下被gin静默结束为200。
+		if _, isJSON := renderer.(jsonRenderer); isJSON {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if fallbackErr := JSONRenderer.Render(c, httpCode, payload); fallbackErr != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package response
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CursorPageResponse 游标分页响应结构
+type CursorPageResponse struct {
+	List       interface{} `json:"list"`        // 数据列表
+	NextCursor string      `json:"next_cursor"` // 下一页游标,没有更多数据时为空
+	PrevCursor string      `json:"prev_cursor"` // 上一页游标,位于首页时为空
+	HasMore    bool        `json:"has_more"`    // 是否还有更多数据
+	PageSize   int         `json:"page_size"`   // 页大小
+}
+
+// cursorSecret 用于对游标签名的HMAC密钥,未设置时游标不做防篡改校验
+var cursorSecret []byte
+
+// SetCursorSecret 设置用于游标签名的HMAC密钥
+func SetCursorSecret(secret string) {
+	cursorSecret = []byte(secret)
+}
+
+// NewCursorPage 构建游标分页响应
+// list: 本页数据列表
+// encodeNextFn: 返回list中最后一行的游标payload(通常是排序字段+唯一ID),没有更多数据时返回nil
+// encodePrevFn: 返回list中第一行的游标payload,位于首页时返回nil;传入nil表示不支持向前翻页
+// pageSize: 页大小
+func NewCursorPage(list interface{}, encodeNextFn, encodePrevFn func() (interface{}, error), pageSize int) (*CursorPageResponse, error) {
+	page := &CursorPageResponse{
+		List:     list,
+		PageSize: pageSize,
+	}
+
+	next, err := encodeNextFn()
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		cursor, err := EncodeCursor(next)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+		page.HasMore = true
+	}
+
+	if encodePrevFn == nil {
+		return page, nil
+	}
+	prev, err := encodePrevFn()
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		cursor, err := EncodeCursor(prev)
+		if err != nil {
+			return nil, err
+		}
+		page.PrevCursor = cursor
+	}
+	return page, nil
+}
+
+// EncodeCursor 将payload编码为base64url游标,若设置了SetCursorSecret则附带HMAC签名
+func EncodeCursor(payload interface{}) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	if len(cursorSecret) == 0 {
+		return base64.RawURLEncoding.EncodeToString(body), nil
+	}
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write(body)
+	signed := append(mac.Sum(nil), body...)
+	return base64.RawURLEncoding.EncodeToString(signed), nil
+}
+
+// ErrInvalidCursor 表示游标无法解码或签名校验失败
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// DecodeCursor 解码并校验cursor,将payload反序列化到out中
+// 游标被篡改或格式错误时返回ErrInvalidCursor
+func DecodeCursor(cursor string, out interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	body := raw
+	if len(cursorSecret) > 0 {
+		if len(raw) < sha256.Size {
+			return ErrInvalidCursor
+		}
+		sig, payload := raw[:sha256.Size], raw[sha256.Size:]
+		mac := hmac.New(sha256.New, cursorSecret)
+		mac.Write(payload)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrInvalidCursor
+		}
+		body = payload
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// SuccessWithCursor 返回游标分页成功响应
+// c: gin上下文
+// message: 自定义消息
+// page: 游标分页数据
+func SuccessWithCursor(c *gin.Context, message string, page *CursorPageResponse) {
+	renderResponse(c, http.StatusOK, &Response{
+		Code:      CodeSuccess,
+		Message:   message,
+		Data:      page,
+		Timestamp: time.Now().Unix(),
+		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
+	})
+}
+
+// BindCursor 从请求中按cursorParam读取游标,解码校验失败时写入400响应并返回false
+// c: gin上下文
+// cursorParam: 游标所在的query参数名,如 "cursor"
+// out: 解码后的游标payload
+func BindCursor(c *gin.Context, cursorParam string, out interface{}) bool {
+	cursor := c.Query(cursorParam)
+	if cursor == "" {
+		return true
+	}
+	if err := DecodeCursor(cursor, out); err != nil {
+		BadRequest(c, "invalid cursor")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package response
+
+import "strconv"
+
+// Envelope 将内部的 Response 结构转换为对外暴露的响应体形状,
+// 使调用方可以在不改变 Success/Error 调用点的情况下切换响应协议。
+type Envelope interface {
+	// Wrap 返回将要序列化给客户端的最终载荷
+	Wrap(resp *Response) interface{}
+}
+
+// defaultEnvelope 保持本包既有的 {code,message,data,error,...} 结构不变
+type defaultEnvelope struct{}
+
+func (defaultEnvelope) Wrap(resp *Response) interface{} {
+	return resp
+}
+
+// jsonAPIError 是 JSONAPIEnvelope 中 errors 数组的单个元素
+type jsonAPIError struct {
+	Status string      `json:"status"`
+	Code   string      `json:"code,omitempty"`
+	Title  string      `json:"title"`
+	Detail interface{} `json:"detail,omitempty"`
+}
+
+// jsonAPIEnvelope 产出 JSON:API (https://jsonapi.org) 风格的 {data, errors, meta} 结构
+type jsonAPIEnvelope struct{}
+
+func (jsonAPIEnvelope) Wrap(resp *Response) interface{} {
+	meta := map[string]interface{}{
+		"timestamp": resp.Timestamp,
+	}
+	if resp.RequestID != "" {
+		meta["request_id"] = resp.RequestID
+	}
+	body := map[string]interface{}{"meta": meta}
+	if resp.Error != nil {
+		body["errors"] = []jsonAPIError{{
+			Status: strconv.Itoa(resp.Code),
+			Code:   resp.Error.Code,
+			Title:  resp.Error.Message,
+			Detail: resp.Error.Details,
+		}}
+		return body
+	}
+	body["data"] = resp.Data
+	return body
+}
+
+// JSONAPIEnvelope 是实现了 JSON:API 响应形状的 Envelope
+var JSONAPIEnvelope Envelope = jsonAPIEnvelope{}
+
+// ginVueAdminEnvelope 产出 gin-vue-admin 惯用的 {code, data, msg} 结构
+type ginVueAdminEnvelope struct{}
+
+func (ginVueAdminEnvelope) Wrap(resp *Response) interface{} {
+	return map[string]interface{}{
+		"code": resp.Code,
+		"data": resp.Data,
+		"msg":  resp.Message,
+	}
+}
+
+// GinVueAdminEnvelope 是实现了 gin-vue-admin 响应形状的 Envelope
+var GinVueAdminEnvelope Envelope = ginVueAdminEnvelope{}
+
+// DefaultEnvelope 是本包原有的 {code,message,data,error,...} Envelope
+var DefaultEnvelope Envelope = defaultEnvelope{}
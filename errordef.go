@@ -0,0 +1,134 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorDef 是一个可注册的业务错误定义,本身即是一个 error
+type ErrorDef struct {
+	Code           string // 业务错误代码,如 "USER_NOT_FOUND"
+	HTTPStatus     int    // 对应的HTTP状态码
+	DefaultMessage string // 默认错误消息
+}
+
+// Error 实现 error 接口
+func (e *ErrorDef) Error() string {
+	return e.DefaultMessage
+}
+
+// Is 使 errors.Is(err, someErrorDef) 能够按错误代码匹配
+func (e *ErrorDef) Is(target error) bool {
+	other, ok := target.(*ErrorDef)
+	if !ok {
+		return false
+	}
+	return other.Code == e.Code
+}
+
+// errorRegistry 保存通过 DefineError 注册的所有错误定义,用于避免Code重复
+var errorRegistry = map[string]*ErrorDef{}
+
+// DefineError 注册一个业务错误定义
+// code: 业务错误代码,重复注册会panic
+// httpStatus: 对应的HTTP状态码
+// defaultMessage: 默认错误消息
+func DefineError(code string, httpStatus int, defaultMessage string) *ErrorDef {
+	if _, exists := errorRegistry[code]; exists {
+		panic("response: error code already defined: " + code)
+	}
+	def := &ErrorDef{Code: code, HTTPStatus: httpStatus, DefaultMessage: defaultMessage}
+	errorRegistry[code] = def
+	return def
+}
+
+// wrappedError 为 ErrorDef 附加详情或覆盖消息,同时保留Unwrap链
+type wrappedError struct {
+	def     *ErrorDef
+	cause   error
+	message string
+	details interface{}
+}
+
+func (w *wrappedError) Error() string {
+	if w.message != "" {
+		return w.message
+	}
+	return w.cause.Error()
+}
+
+func (w *wrappedError) Unwrap() error {
+	return w.cause
+}
+
+// WithDetails 包装err,使 Fail 在响应中附带details
+func WithDetails(err error, details interface{}) error {
+	return wrap(err, "", details)
+}
+
+// WithMessage 包装err,使 Fail 使用msg替换默认错误消息
+func WithMessage(err error, msg string) error {
+	return wrap(err, msg, nil)
+}
+
+func wrap(err error, msg string, details interface{}) error {
+	if w, ok := err.(*wrappedError); ok {
+		next := *w
+		if msg != "" {
+			next.message = msg
+		}
+		if details != nil {
+			next.details = details
+		}
+		return &next
+	}
+	def, _ := err.(*ErrorDef)
+	return &wrappedError{def: def, cause: err, message: msg, details: details}
+}
+
+// Fail 根据err解析出的 *ErrorDef 返回对应的错误响应,找不到时回退到500 InternalError
+// c: gin上下文
+// err: 业务层返回的错误,可以是 *ErrorDef、通过 WithDetails/WithMessage 包装过的错误,
+// 或直接用 fmt.Errorf("...: %w", someErrorDef) 附加了上下文的错误
+func Fail(c *gin.Context, err error) {
+	var def *ErrorDef
+	var message string
+	var details interface{}
+
+	var wrapped *wrappedError
+	if errors.As(err, &wrapped) {
+		message = wrapped.message
+		details = wrapped.details
+	}
+	if !errors.As(err, &def) {
+		// 未注册为 ErrorDef 的错误:默认不把内部错误信息透传给客户端,
+		// 仅在 DebugMode 下把原始错误文本作为 Details 附上,方便排查。
+		if DebugMode {
+			ErrorWithDetails(c, http.StatusInternalServerError, CodeInternalError, ErrorCodeInternalError, "internal error", err.Error())
+			return
+		}
+		InternalError(c, "internal error")
+		return
+	}
+	if message == "" {
+		message = def.DefaultMessage
+	}
+	if details == nil {
+		// err本身由 fmt.Errorf("...: %w", def) 这类方式附加了上下文(而非经WithDetails包装),
+		// 这里把完整的错误文本保留为Details,避免外层%w携带的信息被静默丢弃。
+		if full := err.Error(); full != def.Error() && full != message {
+			details = full
+		}
+	}
+	httpStatus := def.HTTPStatus
+	if httpStatus == 0 {
+		httpStatus = http.StatusInternalServerError
+	}
+	if details != nil {
+		ErrorWithDetails(c, httpStatus, httpStatus, def.Code, message, details)
+		return
+	}
+	Error(c, httpStatus, httpStatus, def.Code, message)
+}
@@ -0,0 +1,195 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 消息ID常量,与业务错误代码一一对应
+const (
+	MsgInvalidParam     = "INVALID_PARAM"
+	MsgInvalidToken     = "INVALID_TOKEN"
+	MsgTokenExpired     = "TOKEN_EXPIRED"
+	MsgUserNotFound     = "USER_NOT_FOUND"
+	MsgUserExists       = "USER_EXISTS"
+	MsgPasswordWrong    = "PASSWORD_WRONG"
+	MsgAccountLocked    = "ACCOUNT_LOCKED"
+	MsgAccountInactive  = "ACCOUNT_INACTIVE"
+	MsgInsufficientPerm = "INSUFFICIENT_PERMISSION"
+	MsgInternalError    = "INTERNAL_ERROR"
+)
+
+// DefaultLang 找不到请求语言或注册消息时使用的兜底语言
+const DefaultLang = "zh-CN"
+
+// langContextKey 中间件写入当前请求语言时使用的上下文键
+const langContextKey = "response_lang"
+
+// Localizer 负责将消息ID和参数渲染为指定语言的文本
+type Localizer interface {
+	// Localize 返回lang语言下msgID对应的渲染文本,找不到时返回空字符串
+	Localize(lang, msgID string, params map[string]interface{}) string
+}
+
+// messageRegistry 默认的内置 Localizer 实现,按 lang -> msgID -> 模板 注册
+type messageRegistry struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+func newMessageRegistry() *messageRegistry {
+	return &messageRegistry{
+		messages: map[string]map[string]string{
+			"zh-CN": {
+				MsgInvalidParam:     "参数无效",
+				MsgInvalidToken:     "令牌无效",
+				MsgTokenExpired:     "令牌已过期",
+				MsgUserNotFound:     "用户不存在",
+				MsgUserExists:       "用户已存在",
+				MsgPasswordWrong:    "密码错误",
+				MsgAccountLocked:    "账号已锁定",
+				MsgAccountInactive:  "账号未激活",
+				MsgInsufficientPerm: "权限不足",
+				MsgInternalError:    "内部错误",
+			},
+			"en": {
+				MsgInvalidParam:     "invalid parameter",
+				MsgInvalidToken:     "invalid token",
+				MsgTokenExpired:     "token expired",
+				MsgUserNotFound:     "user not found",
+				MsgUserExists:       "user already exists",
+				MsgPasswordWrong:    "incorrect password",
+				MsgAccountLocked:    "account locked",
+				MsgAccountInactive:  "account inactive",
+				MsgInsufficientPerm: "insufficient permission",
+				MsgInternalError:    "internal error",
+			},
+		},
+	}
+}
+
+// Register 为lang注册或覆盖一批消息模板
+func (r *messageRegistry) Register(lang string, messages map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.messages[lang] == nil {
+		r.messages[lang] = make(map[string]string)
+	}
+	for id, tmpl := range messages {
+		r.messages[lang][id] = tmpl
+	}
+}
+
+// Localize 实现 Localizer 接口,按 {{key}} 占位符渲染模板
+func (r *messageRegistry) Localize(lang, msgID string, params map[string]interface{}) string {
+	r.mu.RLock()
+	tmpl, ok := r.messages[lang][msgID]
+	r.mu.RUnlock()
+	if !ok {
+		return ""
+	}
+	return renderTemplate(tmpl, params)
+}
+
+// renderTemplate 将模板中的 {{key}} 占位符替换为params中的值
+func renderTemplate(tmpl string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return tmpl
+	}
+	result := tmpl
+	for key, value := range params {
+		result = strings.ReplaceAll(result, "{{"+key+"}}", toString(value))
+	}
+	return result
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+var defaultRegistry = newMessageRegistry()
+
+// activeLocalizer 当前生效的 Localizer,默认使用内置注册表
+var activeLocalizer Localizer = defaultRegistry
+
+// RegisterMessages 向默认 Localizer 注册lang语言下的消息模板
+// lang: 语言标签,如 "zh-CN"、"en"
+// messages: msgID到模板字符串的映射
+func RegisterMessages(lang string, messages map[string]string) {
+	defaultRegistry.Register(lang, messages)
+}
+
+// SetLocalizer 替换全局使用的 Localizer 实现
+func SetLocalizer(l Localizer) {
+	activeLocalizer = l
+}
+
+// resolveLang 从上下文或Accept-Language头中解析请求语言
+func resolveLang(c *gin.Context) string {
+	if lang, exists := c.Get(langContextKey); exists {
+		if s, ok := lang.(string); ok && s != "" {
+			return s
+		}
+	}
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return DefaultLang
+	}
+	if idx := strings.IndexAny(header, ",;"); idx != -1 {
+		header = header[:idx]
+	}
+	return strings.TrimSpace(header)
+}
+
+// localize 解析消息ID,按 请求语言 -> DefaultLang -> 原始消息ID 的顺序回退
+func localize(c *gin.Context, msgID string, params map[string]interface{}) string {
+	lang := resolveLang(c)
+	if msg := activeLocalizer.Localize(lang, msgID, params); msg != "" {
+		return msg
+	}
+	if lang != DefaultLang {
+		if msg := activeLocalizer.Localize(DefaultLang, msgID, params); msg != "" {
+			return msg
+		}
+	}
+	return msgID
+}
+
+// BadRequestL 返回本地化的400错误响应
+// c: gin上下文
+// msgID: 消息ID
+// params: 模板参数
+func BadRequestL(c *gin.Context, msgID string, params map[string]interface{}) {
+	BadRequest(c, localize(c, msgID, params))
+}
+
+// UnauthorizedL 返回本地化的401错误响应
+func UnauthorizedL(c *gin.Context, msgID string, params map[string]interface{}) {
+	Unauthorized(c, localize(c, msgID, params))
+}
+
+// ForbiddenL 返回本地化的403错误响应
+func ForbiddenL(c *gin.Context, msgID string, params map[string]interface{}) {
+	Forbidden(c, localize(c, msgID, params))
+}
+
+// NotFoundL 返回本地化的404错误响应
+func NotFoundL(c *gin.Context, msgID string, params map[string]interface{}) {
+	NotFound(c, localize(c, msgID, params))
+}
+
+// InternalErrorL 返回本地化的500错误响应
+func InternalErrorL(c *gin.Context, msgID string, params map[string]interface{}) {
+	InternalError(c, localize(c, msgID, params))
+}
+
+// SuccessWithMessageL 返回本地化消息的成功响应
+func SuccessWithMessageL(c *gin.Context, msgID string, params map[string]interface{}, data interface{}) {
+	SuccessWithMessage(c, localize(c, msgID, params), data)
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// prometheusMetrics 是基于 Prometheus Histogram 的默认 Metrics 实现
+type prometheusMetrics struct {
+	histogram *prometheus.HistogramVec
+}
+
+// NewPrometheusMetrics 创建按路由模板和业务状态码打标的响应耗时指标
+// histogramName: 指标名称,如 "http_response_duration_seconds"
+func NewPrometheusMetrics(histogramName string) Metrics {
+	histogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: histogramName,
+			Help: "response package handler latency, labeled by route and business code",
+		},
+		[]string{"route", "code"},
+	)
+	prometheus.MustRegister(histogram)
+	return &prometheusMetrics{histogram: histogram}
+}
+
+func (m *prometheusMetrics) Observe(route string, code int, duration time.Duration) {
+	m.histogram.WithLabelValues(route, strconv.Itoa(code)).Observe(duration.Seconds())
+}
@@ -0,0 +1,134 @@
+package response
+
+import (
+	"crypto/rand"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugMode 控制 Middleware 捕获 panic 时是否把堆栈信息写入 Details
+var DebugMode = false
+
+// traceIDContextKey/spanIDContextKey 是 Middleware 写入 trace 信息时使用的上下文键
+const (
+	requestIDContextKey    = "request_id"
+	traceIDContextKey      = "trace_id"
+	spanIDContextKey       = "span_id"
+	responseCodeContextKey = "response_code"
+)
+
+// setResponseCode 记录本次请求实际写出的 Response.Code,供 Middleware 上报指标时读取
+func setResponseCode(c *gin.Context, code int) {
+	c.Set(responseCodeContextKey, code)
+}
+
+// getResponseCode 返回 renderResponse/RenderProblem 记录的业务状态码,
+// 未经由本包写出响应时(如请求提前失败)回退到HTTP状态码
+func getResponseCode(c *gin.Context) int {
+	if v, exists := c.Get(responseCodeContextKey); exists {
+		if code, ok := v.(int); ok {
+			return code
+		}
+	}
+	return c.Writer.Status()
+}
+
+// Metrics 是响应耗时指标的上报接口,内置的Prometheus实现见 NewPrometheusMetrics;
+// Middleware 不会自动启用它,MiddlewareOptions.Metrics 为空时不上报任何指标
+type Metrics interface {
+	// Observe 上报一次请求耗时,route为路由模板(如 /users/:id),
+	// code为本次写出的Response.Code(由renderResponse/RenderProblem记录);
+	// 若请求未经由本包写出响应(如中间件以外提前中断),回退为HTTP状态码
+	Observe(route string, code int, duration time.Duration)
+}
+
+// noopMetrics 是未配置 Metrics 时使用的空实现
+type noopMetrics struct{}
+
+func (noopMetrics) Observe(route string, code int, duration time.Duration) {}
+
+// MiddlewareOptions 配置 Middleware 的可选行为
+type MiddlewareOptions struct {
+	Metrics     Metrics // 为空时不上报耗时指标
+	EnableTrace bool    // 是否解析 traceparent 并写入 Response.TraceID
+}
+
+// Middleware 返回统一处理请求ID、链路追踪、耗时指标与panic兜底的中间件
+func Middleware(opts MiddlewareOptions) gin.HandlerFunc {
+	metrics := opts.Metrics
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Set(requestIDContextKey, requestID)
+		c.Header("X-Request-ID", requestID)
+
+		if opts.EnableTrace {
+			if traceID, spanID, ok := parseTraceparent(c.GetHeader("traceparent")); ok {
+				c.Set(traceIDContextKey, traceID)
+				c.Set(spanIDContextKey, spanID)
+			}
+		}
+
+		start := time.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				details := interface{}(nil)
+				if DebugMode {
+					details = map[string]interface{}{
+						"panic": fmt.Sprint(r),
+						"stack": string(debug.Stack()),
+					}
+				}
+				ErrorWithDetails(c, 500, CodeInternalError, ErrorCodeInternalError, "internal error", details)
+				c.Abort()
+			}
+			metrics.Observe(c.FullPath(), getResponseCode(c), time.Since(start))
+		}()
+
+		c.Next()
+	}
+}
+
+// newRequestID 生成一个 UUIDv7 风格的请求ID,时间有序且无需外部依赖
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	ms := uint64(time.Now().UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	b[6] = (b[6] & 0x0f) | 0x70 // version 7
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// parseTraceparent 解析 W3C traceparent 头 (version-traceid-spanid-flags)
+func parseTraceparent(header string) (traceID, spanID string, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// getTraceID 获取 Middleware 写入的trace ID,未开启追踪时返回空字符串
+func getTraceID(c *gin.Context) string {
+	if v, exists := c.Get(traceIDContextKey); exists {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
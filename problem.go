@@ -0,0 +1,122 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType RFC 7807 响应的内容类型
+const ProblemContentType = "application/problem+json"
+
+// Problem RFC 7807 (https://tools.ietf.org/html/rfc7807) 问题详情结构
+type Problem struct {
+	Type       string                 `json:"type,omitempty"`     // 问题类型的URI
+	Title      string                 `json:"title"`              // 简短的问题摘要
+	Status     int                    `json:"status"`             // HTTP状态码
+	Detail     string                 `json:"detail,omitempty"`   // 针对本次请求的详细说明
+	Instance   string                 `json:"instance,omitempty"` // 标识本次问题实例的URI
+	Extensions map[string]interface{} `json:"-"`                  // 扩展成员,序列化时会被展开到顶层
+}
+
+// MarshalJSON 将 Extensions 中的键展开到 Problem 顶层,符合 RFC 7807 对扩展成员的要求
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"status": p.Status,
+		"title":  p.Title,
+	}
+	if p.Type != "" {
+		fields["type"] = p.Type
+	}
+	if p.Detail != "" {
+		fields["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		fields["instance"] = p.Instance
+	}
+	for k, v := range p.Extensions {
+		fields[k] = v
+	}
+	return json.Marshal(fields)
+}
+
+// problemDetailsEnabled 控制 Error/ErrorWithDetails 是否以 application/problem+json 格式输出
+var problemDetailsEnabled atomic.Bool
+
+// UseProblemDetails 全局开启或关闭 RFC 7807 问题详情格式
+// enabled: true时Error/ErrorWithDetails会以application/problem+json格式响应
+func UseProblemDetails(enabled bool) {
+	problemDetailsEnabled.Store(enabled)
+}
+
+// RenderProblem 返回 RFC 7807 问题详情响应
+// c: gin上下文
+// status: HTTP状态码
+// problem: 问题详情,Status/Instance为空时会自动填充
+func RenderProblem(c *gin.Context, status int, problem *Problem) {
+	if problem.Status == 0 {
+		problem.Status = status
+	}
+	if problem.Instance == "" {
+		problem.Instance = c.Request.URL.Path
+	}
+	setResponseCode(c, problem.Status)
+	c.Header("X-Request-ID", getRequestID(c))
+	body, err := json.Marshal(problem)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, ProblemContentType, body)
+}
+
+// newProblemFromError 根据业务错误码/消息构造一个问题详情
+func newProblemFromError(status int, errorCode, message string, details interface{}) *Problem {
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  errorCode,
+		Status: status,
+		Detail: message,
+	}
+	if details != nil {
+		p.Extensions = map[string]interface{}{"details": details}
+	}
+	return p
+}
+
+// ProblemBadRequest 返回400问题详情响应
+// c: gin上下文
+// message: 问题说明
+func ProblemBadRequest(c *gin.Context, message string) {
+	RenderProblem(c, http.StatusBadRequest, newProblemFromError(http.StatusBadRequest, ErrorCodeInvalidParam, message, nil))
+}
+
+// ProblemUnauthorized 返回401问题详情响应
+// c: gin上下文
+// message: 问题说明
+func ProblemUnauthorized(c *gin.Context, message string) {
+	RenderProblem(c, http.StatusUnauthorized, newProblemFromError(http.StatusUnauthorized, ErrorCodeInvalidToken, message, nil))
+}
+
+// ProblemForbidden 返回403问题详情响应
+// c: gin上下文
+// message: 问题说明
+func ProblemForbidden(c *gin.Context, message string) {
+	RenderProblem(c, http.StatusForbidden, newProblemFromError(http.StatusForbidden, ErrorCodeInsufficientPerm, message, nil))
+}
+
+// ProblemNotFound 返回404问题详情响应
+// c: gin上下文
+// message: 问题说明
+func ProblemNotFound(c *gin.Context, message string) {
+	RenderProblem(c, http.StatusNotFound, newProblemFromError(http.StatusNotFound, ErrorCodeUserNotFound, message, nil))
+}
+
+// ProblemInternalError 返回500问题详情响应
+// c: gin上下文
+// message: 问题说明
+func ProblemInternalError(c *gin.Context, message string) {
+	RenderProblem(c, http.StatusInternalServerError, newProblemFromError(http.StatusInternalServerError, ErrorCodeInternalError, message, nil))
+}
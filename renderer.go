@@ -0,0 +1,73 @@
+package response
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Renderer 负责把 Envelope 产出的载荷序列化并写入响应
+type Renderer interface {
+	// Render 将payload以自身的编码格式写入httpCode状态的响应
+	Render(c *gin.Context, httpCode int, payload interface{}) error
+}
+
+// jsonRenderer 是默认的JSON渲染器,行为与本包历史上的c.JSON一致
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(c *gin.Context, httpCode int, payload interface{}) error {
+	c.JSON(httpCode, payload)
+	return nil
+}
+
+// JSONRenderer 是内置的JSON Renderer
+var JSONRenderer Renderer = jsonRenderer{}
+
+// protobufRenderer 要求payload实现 proto.Message,否则回退到JSON
+type protobufRenderer struct{}
+
+func (protobufRenderer) Render(c *gin.Context, httpCode int, payload interface{}) error {
+	msg, ok := payload.(proto.Message)
+	if !ok {
+		return JSONRenderer.Render(c, httpCode, payload)
+	}
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.Data(httpCode, "application/x-protobuf", body)
+	return nil
+}
+
+// ProtobufRenderer 是内置的Protobuf Renderer,仅当payload实现proto.Message时生效
+var ProtobufRenderer Renderer = protobufRenderer{}
+
+// msgpackRenderer 以 MessagePack 格式编码payload
+type msgpackRenderer struct{}
+
+func (msgpackRenderer) Render(c *gin.Context, httpCode int, payload interface{}) error {
+	body, err := msgpack.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	c.Data(httpCode, "application/msgpack", body)
+	return nil
+}
+
+// MessagePackRenderer 是内置的MessagePack Renderer
+var MessagePackRenderer Renderer = msgpackRenderer{}
+
+// negotiateRenderer 按 Accept 头在内置Renderer间协商,缺省回退到JSON
+func negotiateRenderer(c *gin.Context) Renderer {
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "application/x-protobuf"), strings.Contains(accept, "application/protobuf"):
+		return ProtobufRenderer
+	case strings.Contains(accept, "application/msgpack"), strings.Contains(accept, "application/x-msgpack"):
+		return MessagePackRenderer
+	default:
+		return JSONRenderer
+	}
+}
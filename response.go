@@ -15,6 +15,7 @@ type Response struct {
 	Error     *ErrorInfo  `json:"error,omitempty"`      // 错误信息
 	Timestamp int64       `json:"timestamp"`            // 时间戳
 	RequestID string      `json:"request_id,omitempty"` // 请求ID
+	TraceID   string      `json:"trace_id,omitempty"`   // 链路追踪ID,由Middleware在开启追踪时写入
 }
 
 // ErrorInfo 错误信息结构
@@ -72,12 +73,13 @@ const (
 // c: gin上下文
 // data: 响应数据
 func Success(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, &Response{
+	renderResponse(c, http.StatusOK, &Response{
 		Code:      CodeSuccess,
 		Message:   "success",
 		Data:      data,
 		Timestamp: time.Now().Unix(),
 		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
 	})
 }
 
@@ -86,12 +88,13 @@ func Success(c *gin.Context, data interface{}) {
 // message: 自定义消息
 // data: 响应数据
 func SuccessWithMessage(c *gin.Context, message string, data interface{}) {
-	c.JSON(http.StatusOK, &Response{
+	renderResponse(c, http.StatusOK, &Response{
 		Code:      CodeSuccess,
 		Message:   message,
 		Data:      data,
 		Timestamp: time.Now().Unix(),
 		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
 	})
 }
 
@@ -100,12 +103,13 @@ func SuccessWithMessage(c *gin.Context, message string, data interface{}) {
 // message: 自定义消息
 // page: 分页数据
 func SuccessWithPage(c *gin.Context, message string, page *PageResponse) {
-	c.JSON(http.StatusOK, &Response{
+	renderResponse(c, http.StatusOK, &Response{
 		Code:      CodeSuccess,
 		Message:   message,
 		Data:      page,
 		Timestamp: time.Now().Unix(),
 		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
 	})
 }
 
@@ -116,7 +120,11 @@ func SuccessWithPage(c *gin.Context, message string, page *PageResponse) {
 // errorCode: 错误代码
 // message: 错误消息
 func Error(c *gin.Context, httpCode int, code int, errorCode string, message string) {
-	c.JSON(httpCode, &Response{
+	if problemDetailsEnabled.Load() {
+		RenderProblem(c, httpCode, newProblemFromError(httpCode, errorCode, message, nil))
+		return
+	}
+	renderResponse(c, httpCode, &Response{
 		Code:    code,
 		Message: "error",
 		Error: &ErrorInfo{
@@ -125,6 +133,7 @@ func Error(c *gin.Context, httpCode int, code int, errorCode string, message str
 		},
 		Timestamp: time.Now().Unix(),
 		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
 	})
 }
 
@@ -136,7 +145,11 @@ func Error(c *gin.Context, httpCode int, code int, errorCode string, message str
 // message: 错误消息
 // details: 错误详情
 func ErrorWithDetails(c *gin.Context, httpCode int, code int, errorCode string, message string, details interface{}) {
-	c.JSON(httpCode, &Response{
+	if problemDetailsEnabled.Load() {
+		RenderProblem(c, httpCode, newProblemFromError(httpCode, errorCode, message, details))
+		return
+	}
+	renderResponse(c, httpCode, &Response{
 		Code:    code,
 		Message: "error",
 		Error: &ErrorInfo{
@@ -146,6 +159,7 @@ func ErrorWithDetails(c *gin.Context, httpCode int, code int, errorCode string,
 		},
 		Timestamp: time.Now().Unix(),
 		RequestID: getRequestID(c),
+		TraceID:   getTraceID(c),
 	})
 }
 